@@ -1,9 +1,15 @@
 package data
 
+import "context"
+
 // UserInterface is the interface for the user type. In order
 // to satisfy this interface, all specified methods must be implemented.
 // We do this so we can test things easily. Both data.User and data.UserTest
 // implement this interface.
+//
+// Each method has a Ctx twin that takes a context.Context so long-running
+// queries can be cancelled; the plain methods are kept so existing callers
+// don't have to thread a context through everywhere at once.
 type UserInterface interface {
 	GetAll() ([]*User, error)
 	GetByEmail(email string) (*User, error)
@@ -14,13 +20,61 @@ type UserInterface interface {
 	Insert(user User) (int, error)
 	ResetPassword(password string) error
 	PasswordMatches(plainText string) (bool, error)
+
+	GetAllCtx(ctx context.Context) ([]*User, error)
+	GetByEmailCtx(ctx context.Context, email string) (*User, error)
+	GetOneCtx(ctx context.Context, id int) (*User, error)
+	UpdateCtx(ctx context.Context, user User) error
+	DeleteByIDCtx(ctx context.Context, id int) error
+	InsertCtx(ctx context.Context, user User) (int, error)
+	ResetPasswordCtx(ctx context.Context, password string) error
+	PasswordMatchesCtx(ctx context.Context, plainText string) (bool, error)
 }
 
 // PlanInterface is the type for the plan type. Both data.Plan and data.PlanTest
-// implement this interface.
+// implement this interface. See UserInterface for why each method that
+// queries the database has a Ctx twin; AmountForDisplay only formats a value
+// already in memory, so it has none.
 type PlanInterface interface {
 	GetAll() ([]*Plan, error)
 	GetOne(id int) (*Plan, error)
 	SubscribeUserToPlan(user User, plan Plan) error
 	AmountForDisplay() string
-}
\ No newline at end of file
+
+	GetAllCtx(ctx context.Context) ([]*Plan, error)
+	GetOneCtx(ctx context.Context, id int) (*Plan, error)
+	SubscribeUserToPlanCtx(ctx context.Context, user User, plan Plan) error
+}
+
+// Repository composes UserInterface and PlanInterface and adds operations
+// that touch both the user and plan tables and must succeed or fail
+// together. PostgresRepository and RepositoryTest implement it.
+//
+// UserInterface and PlanInterface both declare a GetAll and a GetOne method
+// with different signatures, so Repository can't embed them directly
+// without an ambiguous selector; Users and Plans expose them instead.
+type Repository interface {
+	Users() UserInterface
+	Plans() PlanInterface
+
+	// SubscribeUserToPlanTx subscribes userID to planID as a single atomic
+	// operation, unlike PlanInterface.SubscribeUserToPlan, which has no
+	// transactional guarantee across the user and plan tables.
+	SubscribeUserToPlanTx(ctx context.Context, userID, planID int) error
+
+	// TransferSubscription moves a user's plan subscription to another user.
+	TransferSubscription(ctx context.Context, fromUserID, toUserID int) error
+
+	// BulkInsertUsers inserts every user in a single statement, returning
+	// their new IDs. The IDs are not guaranteed to come back in the same
+	// order as users: match them up by email if the caller needs to know
+	// which ID belongs to which input. Run it through WithTx if a failure
+	// partway through a larger flow should roll these inserts back too.
+	BulkInsertUsers(ctx context.Context, users []User) ([]int, error)
+
+	// WithTx runs fn with a Repository whose transactional methods above are
+	// scoped to a single *sql.Tx, committing if fn returns nil and rolling
+	// back otherwise, so callers can compose their own atomic multi-step
+	// flows out of them.
+	WithTx(ctx context.Context, fn func(Repository) error) error
+}
@@ -0,0 +1,31 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+)
+
+// dbTimeout bounds how long a plain (non-Ctx) model method may run. The Ctx
+// twins take the caller's own context instead of this default.
+const dbTimeout = time.Second * 3
+
+// db is the connection pool every concrete model in this package queries
+// through. It is set once, by New.
+var db *sql.DB
+
+// Models bundles every concrete data-access type backed by db.
+type Models struct {
+	User User
+	Plan Plan
+}
+
+// New returns a Models backed by dbPool, for use by PostgresRepository or
+// any caller that needs the concrete User/Plan implementations of
+// UserInterface/PlanInterface.
+func New(dbPool *sql.DB) Models {
+	db = dbPool
+	return Models{
+		User: User{},
+		Plan: Plan{},
+	}
+}
@@ -0,0 +1,29 @@
+package data
+
+import (
+	"context"
+	"time"
+)
+
+// Options configures how a Ctx-suffixed method runs, beyond what the caller's
+// context.Context already conveys.
+type Options struct {
+	// Timeout bounds how long a query may run. Zero means no extra bound is
+	// applied beyond whatever deadline ctx already carries.
+	Timeout time.Duration
+}
+
+// WithTimeout builds an Options that bounds a query to d.
+func WithTimeout(d time.Duration) Options {
+	return Options{Timeout: d}
+}
+
+// Context derives a context from ctx with o.Timeout applied, returning ctx
+// unchanged (and a no-op cancel) if no timeout was set. Callers must call
+// the returned cancel func, typically via defer.
+func (o Options) Context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.Timeout)
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+	"fmt"
+)
+
+// PlanTest is a canned, in-memory PlanInterface for tests: no database
+// required. See UserTest for why it's named with a hyphen and why its Ctx
+// methods check ctx.Done() up front.
+type PlanTest struct{}
+
+func (p *PlanTest) GetAll() ([]*Plan, error) {
+	return p.GetAllCtx(context.Background())
+}
+
+func (p *PlanTest) GetAllCtx(ctx context.Context) ([]*Plan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return []*Plan{
+		{ID: 1, PlanName: "Bronze Plan", PlanAmount: 1000},
+		{ID: 2, PlanName: "Gold Plan", PlanAmount: 2000},
+	}, nil
+}
+
+func (p *PlanTest) GetOne(id int) (*Plan, error) {
+	return p.GetOneCtx(context.Background(), id)
+}
+
+func (p *PlanTest) GetOneCtx(ctx context.Context, id int) (*Plan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &Plan{ID: id, PlanName: "Bronze Plan", PlanAmount: 1000}, nil
+}
+
+func (p *PlanTest) SubscribeUserToPlan(user User, plan Plan) error {
+	return p.SubscribeUserToPlanCtx(context.Background(), user, plan)
+}
+
+func (p *PlanTest) SubscribeUserToPlanCtx(ctx context.Context, user User, plan Plan) error {
+	return ctx.Err()
+}
+
+func (p *PlanTest) AmountForDisplay() string {
+	return fmt.Sprintf("$%.2f", float64(1000)/100)
+}
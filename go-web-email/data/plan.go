@@ -0,0 +1,90 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Plan represents a single row in the plans table. Both Plan and PlanTest
+// implement PlanInterface.
+type Plan struct {
+	ID         int
+	PlanName   string
+	PlanAmount int // in cents
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// GetAll returns every plan, bounded by dbTimeout.
+func (p *Plan) GetAll() ([]*Plan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return p.GetAllCtx(ctx)
+}
+
+// GetAllCtx returns every plan.
+func (p *Plan) GetAllCtx(ctx context.Context) ([]*Plan, error) {
+	query := `select id, plan_name, plan_amount, created_at, updated_at from plans order by id`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plans []*Plan
+	for rows.Next() {
+		var one Plan
+		if err := rows.Scan(&one.ID, &one.PlanName, &one.PlanAmount, &one.CreatedAt, &one.UpdatedAt); err != nil {
+			return nil, err
+		}
+		plans = append(plans, &one)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// GetOne returns the plan with the given id, bounded by dbTimeout.
+func (p *Plan) GetOne(id int) (*Plan, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return p.GetOneCtx(ctx, id)
+}
+
+// GetOneCtx returns the plan with the given id.
+func (p *Plan) GetOneCtx(ctx context.Context, id int) (*Plan, error) {
+	query := `select id, plan_name, plan_amount, created_at, updated_at from plans where id = $1`
+
+	var one Plan
+	row := db.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&one.ID, &one.PlanName, &one.PlanAmount, &one.CreatedAt, &one.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &one, nil
+}
+
+// SubscribeUserToPlan links user to plan, bounded by dbTimeout. Unlike
+// Repository.SubscribeUserToPlanTx, this has no transactional guarantee
+// across the user and plan tables.
+func (p *Plan) SubscribeUserToPlan(user User, plan Plan) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return p.SubscribeUserToPlanCtx(ctx, user, plan)
+}
+
+// SubscribeUserToPlanCtx links user to plan. See SubscribeUserToPlan.
+func (p *Plan) SubscribeUserToPlanCtx(ctx context.Context, user User, plan Plan) error {
+	_, err := db.ExecContext(ctx, `
+		insert into user_plans (user_id, plan_id, created_at, updated_at)
+		values ($1, $2, $3, $4)`,
+		user.ID, plan.ID, time.Now(), time.Now())
+	return err
+}
+
+// AmountForDisplay formats PlanAmount (stored in cents) as a dollar string.
+func (p *Plan) AmountForDisplay() string {
+	return fmt.Sprintf("$%.2f", float64(p.PlanAmount)/100)
+}
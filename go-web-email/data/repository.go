@@ -0,0 +1,239 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// querier is satisfied by both *sql.DB and *sql.Tx, so PostgresRepository
+// can run the same queries standalone or scoped to a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// PostgresRepository is the default Repository implementation. Single-entity
+// operations are delegated to whatever UserInterface and PlanInterface
+// implementations the caller supplies (ordinarily data.User and data.Plan);
+// those implementations take a context but not a *sql.Tx, so calls made
+// through Users() and Plans() do not join a transaction started by WithTx.
+type PostgresRepository struct {
+	users UserInterface
+	plans PlanInterface
+	opts  Options
+
+	db querier
+}
+
+// NewRepository builds a PostgresRepository backed by db, delegating
+// single-entity operations to users and plans. opts bounds how long the
+// cross-entity queries below may run.
+func NewRepository(db *sql.DB, users UserInterface, plans PlanInterface, opts Options) *PostgresRepository {
+	return &PostgresRepository{
+		users: users,
+		plans: plans,
+		opts:  opts,
+		db:    db,
+	}
+}
+
+// Users returns the UserInterface this repository delegates user operations to.
+func (r *PostgresRepository) Users() UserInterface { return r.users }
+
+// Plans returns the PlanInterface this repository delegates plan operations to.
+func (r *PostgresRepository) Plans() PlanInterface { return r.plans }
+
+// SubscribeUserToPlanTx subscribes userID to planID as a single statement,
+// so there is nothing left half-applied if it fails partway.
+func (r *PostgresRepository) SubscribeUserToPlanTx(ctx context.Context, userID, planID int) error {
+	ctx, cancel := r.opts.Context(ctx)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, `
+		insert into user_plans (user_id, plan_id, created_at, updated_at)
+		values ($1, $2, now(), now())`,
+		userID, planID)
+	return err
+}
+
+// TransferSubscription moves fromUserID's plan subscription to toUserID. It
+// returns an error if fromUserID had no subscription to move.
+func (r *PostgresRepository) TransferSubscription(ctx context.Context, fromUserID, toUserID int) error {
+	ctx, cancel := r.opts.Context(ctx)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, `
+		update user_plans set user_id = $1, updated_at = now()
+		where user_id = $2`,
+		toUserID, fromUserID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("data: user %d has no subscription to transfer", fromUserID)
+	}
+	return nil
+}
+
+// BulkInsertUsers inserts every user with a single multi-row statement,
+// returning their new IDs. A multi-row INSERT ... RETURNING does not
+// promise to return rows in the same order as the VALUES list, so the IDs
+// here are not matched up with users by position; match them up by email
+// instead if the caller needs to know which ID belongs to which input.
+// Call it through WithTx if the whole batch must succeed or fail together.
+func (r *PostgresRepository) BulkInsertUsers(ctx context.Context, users []User) ([]int, error) {
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	ctx, cancel := r.opts.Context(ctx)
+	defer cancel()
+
+	var placeholders strings.Builder
+	args := make([]any, 0, len(users)*5)
+	for i, u := range users {
+		if i > 0 {
+			placeholders.WriteString(", ")
+		}
+		n := i * 5
+		fmt.Fprintf(&placeholders, "($%d, $%d, $%d, $%d, $%d, now(), now())", n+1, n+2, n+3, n+4, n+5)
+		args = append(args, u.Email, u.FirstName, u.LastName, u.Password, u.Active)
+	}
+
+	query := fmt.Sprintf(`
+		insert into users (email, first_name, last_name, password, user_active, created_at, updated_at)
+		values %s
+		returning id`, placeholders.String())
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("bulk insert %d users: %w", len(users), err)
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0, len(users))
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("bulk insert %d users: %w", len(users), err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("bulk insert %d users: %w", len(users), err)
+	}
+	return ids, nil
+}
+
+// WithTx runs fn with a Repository scoped to a single *sql.Tx: the
+// transactional methods above (SubscribeUserToPlanTx, TransferSubscription,
+// BulkInsertUsers) that fn calls on it run inside that transaction, which is
+// committed if fn returns nil and rolled back otherwise. Calls made through
+// Users() or Plans() are not part of the transaction, since UserInterface
+// and PlanInterface implementations operate on their own connection.
+func (r *PostgresRepository) WithTx(ctx context.Context, fn func(Repository) error) error {
+	db, ok := r.db.(*sql.DB)
+	if !ok {
+		return fmt.Errorf("data: WithTx called on a repository already inside a transaction")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	scoped := &PostgresRepository{
+		users: r.users,
+		plans: r.plans,
+		opts:  r.opts,
+		db:    tx,
+	}
+
+	if err := fn(scoped); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RepositoryTest is an in-memory Repository for tests, following the same
+// pattern as UserTest and PlanTest: no database required.
+type RepositoryTest struct {
+	users UserInterface
+	plans PlanInterface
+
+	mu            sync.Mutex
+	subscriptions map[int]int // userID -> planID
+}
+
+// NewRepositoryTest builds a RepositoryTest, delegating single-entity
+// operations to users and plans.
+func NewRepositoryTest(users UserInterface, plans PlanInterface) *RepositoryTest {
+	return &RepositoryTest{
+		users:         users,
+		plans:         plans,
+		subscriptions: make(map[int]int),
+	}
+}
+
+// Users returns the UserInterface this repository delegates user operations to.
+func (r *RepositoryTest) Users() UserInterface { return r.users }
+
+// Plans returns the PlanInterface this repository delegates plan operations to.
+func (r *RepositoryTest) Plans() PlanInterface { return r.plans }
+
+func (r *RepositoryTest) SubscribeUserToPlanTx(_ context.Context, userID, planID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[userID] = planID
+	return nil
+}
+
+func (r *RepositoryTest) TransferSubscription(_ context.Context, fromUserID, toUserID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	planID, ok := r.subscriptions[fromUserID]
+	if !ok {
+		return fmt.Errorf("data: user %d has no subscription to transfer", fromUserID)
+	}
+	delete(r.subscriptions, fromUserID)
+	r.subscriptions[toUserID] = planID
+	return nil
+}
+
+// BulkInsertUsers inserts each user through r.users in turn, so a test
+// asserting on the result of BulkInsertUsers can also look the users back up
+// through Users(), the same as it could against PostgresRepository.
+func (r *RepositoryTest) BulkInsertUsers(ctx context.Context, users []User) ([]int, error) {
+	ids := make([]int, 0, len(users))
+	for _, u := range users {
+		id, err := r.users.InsertCtx(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("insert user %q: %w", u.Email, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// WithTx runs fn against the same RepositoryTest; there is no real
+// transaction to roll back, so a prior BulkInsertUsers' assigned IDs are
+// simply left consumed if fn returns an error.
+func (r *RepositoryTest) WithTx(_ context.Context, fn func(Repository) error) error {
+	return fn(r)
+}
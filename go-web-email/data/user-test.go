@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"errors"
+)
+
+// UserTest is a canned, in-memory UserInterface for tests: no database
+// required. Named with a hyphen (not "_test.go") so it's importable from
+// real _test.go files in other packages, not just this one.
+//
+// Its Ctx methods check ctx.Done() before doing anything else, so a test
+// that wants to exercise a timeout can pass an already-canceled or expired
+// context and deterministically get ctx.Err() back, instead of racing a
+// real one against however long this mock happens to take.
+type UserTest struct {
+	nextID int
+}
+
+func (u *UserTest) GetAll() ([]*User, error) {
+	return u.GetAllCtx(context.Background())
+}
+
+func (u *UserTest) GetAllCtx(ctx context.Context) ([]*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return []*User{
+		{ID: 1, Email: "admin@example.com", FirstName: "Admin", LastName: "User", Active: 1},
+		{ID: 2, Email: "jack@example.com", FirstName: "Jack", LastName: "Smith", Active: 1},
+	}, nil
+}
+
+func (u *UserTest) GetByEmail(email string) (*User, error) {
+	return u.GetByEmailCtx(context.Background(), email)
+}
+
+func (u *UserTest) GetByEmailCtx(ctx context.Context, email string) (*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &User{ID: 1, Email: email, FirstName: "Admin", LastName: "User", Active: 1}, nil
+}
+
+func (u *UserTest) GetOne(id int) (*User, error) {
+	return u.GetOneCtx(context.Background(), id)
+}
+
+func (u *UserTest) GetOneCtx(ctx context.Context, id int) (*User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &User{ID: id, Email: "admin@example.com", FirstName: "Admin", LastName: "User", Active: 1}, nil
+}
+
+func (u *UserTest) Update(user User) error {
+	return u.UpdateCtx(context.Background(), user)
+}
+
+func (u *UserTest) UpdateCtx(ctx context.Context, user User) error {
+	return ctx.Err()
+}
+
+func (u *UserTest) DeleteByID(id int) error {
+	return u.DeleteByIDCtx(context.Background(), id)
+}
+
+func (u *UserTest) DeleteByIDCtx(ctx context.Context, id int) error {
+	return ctx.Err()
+}
+
+func (u *UserTest) Insert(user User) (int, error) {
+	return u.InsertCtx(context.Background(), user)
+}
+
+// InsertCtx hands out sequential ids starting from 1, so a caller that
+// inserts several users in a row (e.g. Repository.BulkInsertUsers) can
+// still tell them apart.
+func (u *UserTest) InsertCtx(ctx context.Context, user User) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	u.nextID++
+	return u.nextID, nil
+}
+
+func (u *UserTest) ResetPassword(password string) error {
+	return u.ResetPasswordCtx(context.Background(), password)
+}
+
+func (u *UserTest) ResetPasswordCtx(ctx context.Context, password string) error {
+	return ctx.Err()
+}
+
+func (u *UserTest) PasswordMatches(plainText string) (bool, error) {
+	return u.PasswordMatchesCtx(context.Background(), plainText)
+}
+
+func (u *UserTest) PasswordMatchesCtx(ctx context.Context, plainText string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if plainText == "" {
+		return false, errors.New("data: empty password")
+	}
+	return true, nil
+}
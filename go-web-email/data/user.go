@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User represents a single row in the users table. Both User and UserTest
+// implement UserInterface.
+type User struct {
+	ID        int
+	Email     string
+	FirstName string
+	LastName  string
+	Password  string
+	Active    int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// GetAll returns every user, ordered by last name, bounded by dbTimeout.
+func (u *User) GetAll() ([]*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.GetAllCtx(ctx)
+}
+
+// GetAllCtx returns every user, ordered by last name.
+func (u *User) GetAllCtx(ctx context.Context) ([]*User, error) {
+	query := `
+		select id, email, first_name, last_name, password, user_active, created_at, updated_at
+		from users
+		order by last_name`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		var one User
+		if err := rows.Scan(&one.ID, &one.Email, &one.FirstName, &one.LastName,
+			&one.Password, &one.Active, &one.CreatedAt, &one.UpdatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, &one)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// GetByEmail returns the user with the given email, bounded by dbTimeout.
+func (u *User) GetByEmail(email string) (*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.GetByEmailCtx(ctx, email)
+}
+
+// GetByEmailCtx returns the user with the given email.
+func (u *User) GetByEmailCtx(ctx context.Context, email string) (*User, error) {
+	query := `
+		select id, email, first_name, last_name, password, user_active, created_at, updated_at
+		from users
+		where email = $1`
+
+	var one User
+	row := db.QueryRowContext(ctx, query, email)
+	err := row.Scan(&one.ID, &one.Email, &one.FirstName, &one.LastName,
+		&one.Password, &one.Active, &one.CreatedAt, &one.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &one, nil
+}
+
+// GetOne returns the user with the given id, bounded by dbTimeout.
+func (u *User) GetOne(id int) (*User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.GetOneCtx(ctx, id)
+}
+
+// GetOneCtx returns the user with the given id.
+func (u *User) GetOneCtx(ctx context.Context, id int) (*User, error) {
+	query := `
+		select id, email, first_name, last_name, password, user_active, created_at, updated_at
+		from users
+		where id = $1`
+
+	var one User
+	row := db.QueryRowContext(ctx, query, id)
+	err := row.Scan(&one.ID, &one.Email, &one.FirstName, &one.LastName,
+		&one.Password, &one.Active, &one.CreatedAt, &one.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &one, nil
+}
+
+// Update updates every mutable field of user, bounded by dbTimeout.
+func (u *User) Update(user User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.UpdateCtx(ctx, user)
+}
+
+// UpdateCtx updates every mutable field of user.
+func (u *User) UpdateCtx(ctx context.Context, user User) error {
+	query := `
+		update users set
+			email = $1, first_name = $2, last_name = $3, user_active = $4, updated_at = $5
+		where id = $6`
+
+	_, err := db.ExecContext(ctx, query,
+		user.Email, user.FirstName, user.LastName, user.Active, time.Now(), user.ID)
+	return err
+}
+
+// DeleteByID deletes the user with the given id, bounded by dbTimeout.
+func (u *User) DeleteByID(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.DeleteByIDCtx(ctx, id)
+}
+
+// DeleteByIDCtx deletes the user with the given id.
+func (u *User) DeleteByIDCtx(ctx context.Context, id int) error {
+	_, err := db.ExecContext(ctx, `delete from users where id = $1`, id)
+	return err
+}
+
+// Insert inserts user and returns its new id, bounded by dbTimeout.
+func (u *User) Insert(user User) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.InsertCtx(ctx, user)
+}
+
+// InsertCtx inserts user and returns its new id.
+func (u *User) InsertCtx(ctx context.Context, user User) (int, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+
+	var newID int
+	query := `
+		insert into users (email, first_name, last_name, password, user_active, created_at, updated_at)
+		values ($1, $2, $3, $4, $5, $6, $7)
+		returning id`
+
+	err = db.QueryRowContext(ctx, query,
+		user.Email, user.FirstName, user.LastName, hashed, user.Active, time.Now(), time.Now(),
+	).Scan(&newID)
+	if err != nil {
+		return 0, err
+	}
+	return newID, nil
+}
+
+// ResetPassword sets u's password to password, bounded by dbTimeout.
+func (u *User) ResetPassword(password string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.ResetPasswordCtx(ctx, password)
+}
+
+// ResetPasswordCtx sets u's password to password.
+func (u *User) ResetPasswordCtx(ctx context.Context, password string) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `update users set password = $1 where id = $2`, hashed, u.ID)
+	return err
+}
+
+// PasswordMatches reports whether plainText is u's password, bounded by
+// dbTimeout.
+func (u *User) PasswordMatches(plainText string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+	return u.PasswordMatchesCtx(ctx, plainText)
+}
+
+// PasswordMatchesCtx reports whether plainText is u's password.
+func (u *User) PasswordMatchesCtx(ctx context.Context, plainText string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(plainText))
+	switch {
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
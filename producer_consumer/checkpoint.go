@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"time"
+)
+
+// OrderStatus is the last known state of a pizza order, as recorded in a
+// Checkpoint. An order with no entry at all was never reached before the
+// run ended; there's no separate "pending" status for that since nothing
+// is recorded until an order has either succeeded or failed for good.
+type OrderStatus string
+
+const (
+	StatusSuccess OrderStatus = "success"
+	StatusFailed  OrderStatus = "failed"
+)
+
+// CheckpointEntry records what happened to a single pizza order.
+type CheckpointEntry struct {
+	Status  OrderStatus `json:"status"`
+	Reason  string      `json:"reason,omitempty"`
+	Retries int         `json:"retries,omitempty"`
+}
+
+// Checkpoint is the on-disk record of a RunWithCheckpoint run: the status of
+// every order seen so far, and when (if ever) the run finished. A run whose
+// CompletedAt is nil was interrupted and can be resumed.
+type Checkpoint struct {
+	Orders      map[int]*CheckpointEntry `json:"orders"`
+	CompletedAt *time.Time               `json:"completed_at,omitempty"`
+}
+
+// loadCheckpoint reads a Checkpoint from path, returning a fresh, empty one
+// if the file doesn't exist yet.
+func loadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Checkpoint{Orders: make(map[int]*CheckpointEntry)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cp Checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	if cp.Orders == nil {
+		cp.Orders = make(map[int]*CheckpointEntry)
+	}
+	return &cp, nil
+}
+
+// save writes the checkpoint to path, via a temp file and rename so a crash
+// mid-write can never leave a corrupt checkpoint behind.
+func (cp *Checkpoint) save(path string) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
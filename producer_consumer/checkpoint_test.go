@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCheckpointMissingFile(t *testing.T) {
+	cp, err := loadCheckpoint(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+	if cp.Orders == nil || len(cp.Orders) != 0 {
+		t.Errorf("Orders = %v, want an empty, non-nil map", cp.Orders)
+	}
+	if cp.CompletedAt != nil {
+		t.Errorf("CompletedAt = %v, want nil", cp.CompletedAt)
+	}
+}
+
+func TestCheckpointSaveAndLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	now := time.Now().Round(time.Second)
+	cp := &Checkpoint{
+		Orders: map[int]*CheckpointEntry{
+			1: {Status: StatusSuccess},
+			2: {Status: StatusFailed, Reason: "ran out of Anchovies", Retries: 3},
+		},
+		CompletedAt: &now,
+	}
+	if err := cp.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("loadCheckpoint: %v", err)
+	}
+
+	if len(loaded.Orders) != 2 {
+		t.Fatalf("len(Orders) = %d, want 2", len(loaded.Orders))
+	}
+	if loaded.Orders[1].Status != StatusSuccess {
+		t.Errorf("Orders[1].Status = %q, want %q", loaded.Orders[1].Status, StatusSuccess)
+	}
+	if loaded.Orders[2].Status != StatusFailed || loaded.Orders[2].Retries != 3 {
+		t.Errorf("Orders[2] = %+v, want Status=%q Retries=3", loaded.Orders[2], StatusFailed)
+	}
+	if loaded.CompletedAt == nil || !loaded.CompletedAt.Equal(now) {
+		t.Errorf("CompletedAt = %v, want %v", loaded.CompletedAt, now)
+	}
+}
+
+func TestCheckpointSaveLeavesNoTempFileBehind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	cp := &Checkpoint{Orders: make(map[int]*CheckpointEntry)}
+	if err := cp.save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(%s) = %v, want a not-exist error: save should have renamed it away", path+".tmp", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Package inventory provides a small pub/sub mechanism for broadcasting
+// ingredient stock changes to anyone interested in watching them, such as a
+// restocker goroutine or a dashboard.
+package inventory
+
+import (
+	"fmt"
+	"sync"
+)
+
+// StockChange describes the remaining quantity of an ingredient after some
+// event changed it (a pizza was baked, a restock happened, ...).
+type StockChange struct {
+	Ingredient string
+	Remaining  int
+}
+
+// Snapshot is the known stock level for every ingredient a stream has seen a
+// StockChange for, as of the moment a fork subscribed.
+type Snapshot map[string]int
+
+// stream is a single named feed of StockChange events: producers publish
+// into in, and every fork gets a copy of each event plus the running state
+// needed to hand out snapshots to new forks.
+type stream struct {
+	in    chan StockChange
+	mu    sync.Mutex
+	state Snapshot
+	forks []chan StockChange
+}
+
+// Publisher is a registry of named StockChange streams. Producers publish
+// into a stream with CreateStream, and subscribers receive a snapshot plus
+// subsequent deltas with ForkStream.
+type Publisher struct {
+	mu      sync.Mutex
+	streams map[string]*stream
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates an empty Publisher.
+func New() *Publisher {
+	return &Publisher{
+		streams: make(map[string]*stream),
+		quit:    make(chan struct{}),
+	}
+}
+
+// CreateStream registers a named stream if it doesn't already exist and
+// returns the channel producers should publish StockChange events into.
+func (p *Publisher) CreateStream(name string) chan<- StockChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, ok := p.streams[name]
+	if !ok {
+		s = &stream{
+			in:    make(chan StockChange),
+			state: make(Snapshot),
+		}
+		p.streams[name] = s
+		p.wg.Add(1)
+		go p.fanOut(s)
+	}
+
+	return s.in
+}
+
+// fanOut reads every StockChange published to s.in, updates the stream's
+// running snapshot, and forwards the event to every current fork.
+func (p *Publisher) fanOut(s *stream) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case change, ok := <-s.in:
+			if !ok {
+				return
+			}
+
+			s.mu.Lock()
+			s.state[change.Ingredient] = change.Remaining
+			forks := append([]chan StockChange(nil), s.forks...)
+			s.mu.Unlock()
+
+			for _, fork := range forks {
+				select {
+				case fork <- change:
+				case <-p.quit:
+					return
+				}
+			}
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// ForkStream subscribes ch to the named stream. It returns the stream's
+// current Snapshot so the fork starts from the latest known state, then
+// streams every subsequent StockChange into ch.
+func (p *Publisher) ForkStream(name string, ch chan StockChange) (Snapshot, error) {
+	p.mu.Lock()
+	s, ok := p.streams[name]
+	p.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("inventory: no such stream %q", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := make(Snapshot, len(s.state))
+	for ingredient, remaining := range s.state {
+		snapshot[ingredient] = remaining
+	}
+	s.forks = append(s.forks, ch)
+
+	return snapshot, nil
+}
+
+// Shutdown stops every stream's fan-out goroutine and waits for them to
+// exit. The Publisher must not be used afterwards.
+func (p *Publisher) Shutdown() {
+	close(p.quit)
+	p.wg.Wait()
+}
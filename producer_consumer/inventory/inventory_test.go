@@ -0,0 +1,61 @@
+package inventory
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForkStreamSnapshotAndDeltas(t *testing.T) {
+	p := New()
+	defer p.Shutdown()
+
+	in := p.CreateStream("cheese")
+	in <- StockChange{Ingredient: "Mozzarella", Remaining: 10}
+
+	// ForkStream only ever reads state under the stream's own lock, so
+	// polling it with a throwaway fork is a safe, race-free way to wait for
+	// fanOut to have applied the send above before registering the real one.
+	// Throwaway forks are never drained, and one registered before fanOut
+	// applies the first send receives both sends, so each is buffered for
+	// two to keep fanOut from blocking on it.
+	var snapshot Snapshot
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		var err error
+		snapshot, err = p.ForkStream("cheese", make(chan StockChange, 2))
+		if err != nil {
+			t.Fatalf("ForkStream: %v", err)
+		}
+		if snapshot["Mozzarella"] == 10 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if snapshot["Mozzarella"] != 10 {
+		t.Fatalf("snapshot[Mozzarella] = %d, want 10", snapshot["Mozzarella"])
+	}
+
+	fork := make(chan StockChange, 1)
+	if _, err := p.ForkStream("cheese", fork); err != nil {
+		t.Fatalf("ForkStream: %v", err)
+	}
+
+	in <- StockChange{Ingredient: "Mozzarella", Remaining: 9}
+	select {
+	case change := <-fork:
+		if change.Remaining != 9 {
+			t.Errorf("change.Remaining = %d, want 9", change.Remaining)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for forked StockChange")
+	}
+}
+
+func TestForkStreamUnknownStream(t *testing.T) {
+	p := New()
+	defer p.Shutdown()
+
+	if _, err := p.ForkStream("does-not-exist", make(chan StockChange)); err == nil {
+		t.Error("ForkStream on an unregistered name: got nil error, want one")
+	}
+}
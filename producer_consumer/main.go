@@ -1,28 +1,46 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
+
+	"producer_consumer/inventory"
+	"producer_consumer/pipeline"
 )
 
 const NumberOfPizzas = 10
 
-var pizzasMade, pizzasFailed, total int
+// RestockThreshold is how low an ingredient's stock can drop before the
+// restocker tops it back up.
+const RestockThreshold = 2
 
-// Ingredient represents an ingredient with its name and quantity.
-type Ingredient struct {
-	Name     string
-	Quantity int
-}
+// RestockAmount is how much stock the restocker adds back each time it acts.
+const RestockAmount = 5
 
-// PizzaType represents a pizza type with its name and required ingredients.
-type PizzaType struct {
-	Name        string
-	Ingredients []Ingredient
-}
+// ingredientStream is the name of the inventory stream pizzeria ingredient
+// levels are published on.
+const ingredientStream = "ingredients"
+
+// DefaultMaxRetries is how many times bake will retry an order that failed
+// because an ingredient ran out, before giving up on it for good.
+const DefaultMaxRetries = 3
+
+// retryBaseDelay and retryJitter control the backoff between retries: each
+// attempt waits retryBaseDelay*attempt, plus a random amount up to
+// retryJitter, so a restock has a chance to land before the next try.
+const (
+	retryBaseDelay = 200 * time.Millisecond
+	retryJitter    = 150 * time.Millisecond
+)
 
 // AvailableIngredients represents the stock of available ingredients.
 var AvailableIngredients = map[string]int{
@@ -36,133 +54,443 @@ var AvailableIngredients = map[string]int{
 	"Olive oil":     3,
 }
 
-// Producer is a type for structs that holds two channels: one for pizzas, with all
-// information for a given pizza order including whether it was made
-// successfully, and another to handle end of processing (when we quit the channel)
+// WorkerStats holds the made/failed counts for a single bake-stage worker.
+// Each worker keeps its own counters so the pizzeria no longer needs to
+// share mutable state across goroutines just to report on its day.
+type WorkerStats struct {
+	WorkerID int
+	Made     int
+	Failed   int
+}
+
+// Producer runs the pizzeria as a staged pipeline: orderGen -> ingredientCheck
+// -> bake -> box -> deliver. Each stage is its own goroutine with its own
+// error channel, wired together by a pipeline.Pipeline.
 type Producer struct {
-	data            chan PizzaOrder
-	quit            chan chan error
-	wg              *sync.WaitGroup
+	nWorkers    int
+	maxRetries  int
+	pizzaTypes  []pipeline.PizzaType
+	skipNumbers map[int]bool // order numbers already known to have succeeded
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	errs   <-chan error
+
+	sem chan struct{} // bounds the number of pizzas being baked at once
+
+	inv     *inventory.Publisher
+	stockIn chan<- inventory.StockChange
+
+	checkpoint      *Checkpoint
+	checkpointPath  string
+	checkpointMutex sync.Mutex
+
 	ingredientMutex sync.Mutex
+	statsMutex      sync.Mutex
+	stats           []WorkerStats
+}
+
+// NewProducer creates a Producer ready to have its pipeline started.
+// nWorkers controls how many bake-stage goroutines run concurrently,
+// maxInFlight bounds how many pizzas may be baking across all of them at
+// once, and pizzaTypes is the menu orders are generated from.
+func NewProducer(nWorkers, maxInFlight int, pizzaTypes []pipeline.PizzaType) *Producer {
+	inv := inventory.New()
+	return &Producer{
+		nWorkers:   nWorkers,
+		maxRetries: DefaultMaxRetries,
+		pizzaTypes: pizzaTypes,
+		sem:        make(chan struct{}, maxInFlight),
+		inv:        inv,
+		stockIn:    inv.CreateStream(ingredientStream),
+	}
+}
+
+// Start builds and runs the pizza pipeline: orderGen -> ingredientCheck ->
+// bake -> box -> deliver. It returns the final stage's output channel. The
+// pipeline is cancelled, and any in-flight pizzas allowed to finish, when
+// ctx is done.
+func (p *Producer) Start(ctx context.Context) <-chan pipeline.PizzaOrder {
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	return p.run()
+}
+
+// RunWithCheckpoint is like Start, but persists per-order status to path
+// after every completed order. If path already holds a checkpoint from an
+// interrupted run, orders already recorded as successful are skipped and
+// only the pending/failed ones are retried.
+func (p *Producer) RunWithCheckpoint(ctx context.Context, path string) (<-chan pipeline.PizzaOrder, error) {
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		return nil, fmt.Errorf("producer: load checkpoint: %w", err)
+	}
+	if cp.CompletedAt != nil {
+		cp = &Checkpoint{Orders: make(map[int]*CheckpointEntry)}
+	}
+
+	p.checkpoint = cp
+	p.checkpointPath = path
+	p.skipNumbers = make(map[int]bool, len(cp.Orders))
+	for number, entry := range cp.Orders {
+		if entry.Status == StatusSuccess {
+			p.skipNumbers[number] = true
+		}
+	}
+
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	return p.run(), nil
+}
+
+// run wires up and starts the pipeline stages, adding the checkpoint stage
+// only when RunWithCheckpoint configured one.
+func (p *Producer) run() <-chan pipeline.PizzaOrder {
+	stages := []pipeline.Stage{p.orderGen, p.ingredientCheck, p.bake, p.box, p.deliver}
+	if p.checkpointPath != "" {
+		stages = append(stages, p.recordCheckpoint)
+	}
+
+	pl := pipeline.New()
+	out, errs := pl.Run(p.ctx, nil, stages...)
+	p.errs = errs
+
+	go p.logErrors()
+	go p.restocker()
+
+	return out
+}
+
+// restocker watches the ingredient stream and tops up any ingredient that
+// drops below RestockThreshold, publishing the resulting level back onto the
+// stream so other observers see the update too.
+func (p *Producer) restocker() {
+	ch := make(chan inventory.StockChange, 8)
+	if _, err := p.inv.ForkStream(ingredientStream, ch); err != nil {
+		color.Red("restocker: %v", err)
+		return
+	}
+
+	for {
+		select {
+		case change, ok := <-ch:
+			if !ok {
+				return
+			}
+			if change.Remaining >= RestockThreshold {
+				continue
+			}
+
+			p.ingredientMutex.Lock()
+			AvailableIngredients[change.Ingredient] += RestockAmount
+			remaining := AvailableIngredients[change.Ingredient]
+			p.ingredientMutex.Unlock()
+
+			color.Yellow("Restocked %s, now have %d", change.Ingredient, remaining)
+
+			select {
+			case p.stockIn <- inventory.StockChange{Ingredient: change.Ingredient, Remaining: remaining}:
+			case <-p.ctx.Done():
+				return
+			}
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// orderGen is the source stage: it ignores in and generates an order for
+// each number from 1 to NumberOfPizzas that is not in skipNumbers, one pizza
+// type chosen at random for each.
+func (p *Producer) orderGen(ctx context.Context, _ <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	out := make(chan pipeline.PizzaOrder)
+	errs := make(chan error)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+		for i := 1; i <= NumberOfPizzas; i++ {
+			if p.skipNumbers[i] {
+				continue
+			}
+			pizzaType := p.pizzaTypes[rand.Intn(len(p.pizzaTypes))]
+			select {
+			case out <- pipeline.PizzaOrder{PizzaNumber: i, PizzaType: pizzaType}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, errs
 }
 
-// PizzaOrder is a type for structs that describes a given pizza order. It has the order
-// number, a message indicating what happened to the order, and a boolean
-// indicating if the order was successfully completed.
-type PizzaOrder struct {
-	pizzaNumber int
-	message     string
-	success     bool
-}
-
-// Close is simply a method of closing the channel when we are done with it (i.e.
-// something is pushed to the quit channel)
-func (p *Producer) Close() error {
-	ch := make(chan error)
-	p.quit <- ch
-	return <-ch
-}
-
-// makePizza attempts to make a pizza. If the required ingredients for the pizza type
-// are available, it decrements the ingredient quantities and marks the pizza as successful.
-// Otherwise, it marks the pizza as failed.
-func makePizza(pizzaNumber int, pizzaType PizzaType) *PizzaOrder {
-	pizzaNumber++
-	if pizzaNumber <= NumberOfPizzas {
-		msg := ""
-		success := false
-		total++
-
-		pizzaIngredients := pizzaType.Ingredients
-		for _, ingredient := range pizzaIngredients {
-			if AvailableIngredients[ingredient.Name] < ingredient.Quantity {
-				pizzasFailed++
-				msg = fmt.Sprintf("*** We ran out of %s for pizza #%d!", ingredient.Name, pizzaNumber)
-				p := PizzaOrder{
-					pizzaNumber: pizzaNumber,
-					message:     msg,
-					success:     success,
+// ingredientCheck is a deliberate no-op stage, kept only as a named insertion
+// point in the pipeline for middleware like logging or metrics. It does NOT
+// check ingredient levels: that originally-planned split, checking here and
+// reserving stock in bake, was abandoned because levels can change between
+// this stage and bake running (other workers and the restocker are both
+// adjusting them concurrently), so a check here would just be redone,
+// under lock, in attemptBake anyway. Flagging this explicitly because the
+// stage name alone reads as if the check happens here.
+func (p *Producer) ingredientCheck(ctx context.Context, in <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	return passThrough(ctx, in)
+}
+
+// bake is the fan-out stage: nWorkers goroutines pull orders and bake them,
+// checking and reserving ingredients under lock in attemptBake, bounded by
+// the semaphore so only so many pizzas are being baked at once. Each worker tracks its own
+// WorkerStats, reported once it drains its input.
+func (p *Producer) bake(ctx context.Context, in <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	out := make(chan pipeline.PizzaOrder)
+	errs := make(chan error)
+
+	var wg sync.WaitGroup
+	wg.Add(p.nWorkers)
+	for id := 1; id <= p.nWorkers; id++ {
+		go func(id int) {
+			defer wg.Done()
+			stats := WorkerStats{WorkerID: id}
+
+			for o := range in {
+				baked, done := p.bakeWithRetries(ctx, o)
+				if !done {
+					p.reportStats(stats)
+					return
 				}
-				return &p
+				if baked.Success {
+					stats.Made++
+				} else {
+					stats.Failed++
+				}
+
+				// A blocking send, deliberately not raced against ctx.Done:
+				// baked already has its final verdict, so it must be
+				// forwarded rather than dropped (see passThrough).
+				out <- baked
 			}
+
+			p.reportStats(stats)
+		}(id)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+		close(errs)
+	}()
+
+	return out, errs
+}
+
+// bakeWithRetries tries to bake o, retrying with jittered backoff up to
+// maxRetries times if the reason it failed was a missing ingredient (which a
+// restock might resolve before the next attempt). It returns the final
+// order and false if ctx was cancelled before a verdict was reached.
+func (p *Producer) bakeWithRetries(ctx context.Context, o pipeline.PizzaOrder) (pipeline.PizzaOrder, bool) {
+	for attempt := 0; ; attempt++ {
+		baked, ok := p.attemptBake(ctx, o)
+		if ok {
+			baked.Retries = attempt
+			return baked, true
+		}
+		o = baked
+		o.Retries = attempt
+		if attempt >= p.maxRetries {
+			return o, true
 		}
 
-		for _, ingredient := range pizzaIngredients {
-			decrementIngredient(ingredient.Name, ingredient.Quantity)
+		backoff := time.Duration(attempt+1)*retryBaseDelay + time.Duration(rand.Int63n(int64(retryJitter)))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return o, false
 		}
+	}
+}
 
-		pizzasMade++
+// attemptBake makes a single attempt to bake o, bounded by the in-flight
+// semaphore. If an ingredient is short, it publishes the new stock level so
+// the restocker can act on it, and returns ok=false with a failure message.
+func (p *Producer) attemptBake(ctx context.Context, o pipeline.PizzaOrder) (pipeline.PizzaOrder, bool) {
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return o, false
+	}
+	defer func() { <-p.sem }()
 
-		msgDelay := fmt.Sprintf("Received order #%d! for %s", pizzaNumber, pizzaType.Name)
-		color.Cyan(msgDelay)
+	p.ingredientMutex.Lock()
+	for _, ingredient := range o.PizzaType.Ingredients {
+		if AvailableIngredients[ingredient.Name] < ingredient.Quantity {
+			short := AvailableIngredients[ingredient.Name]
+			p.ingredientMutex.Unlock()
 
-		success = true
-		msg = fmt.Sprintf("Pizza order #%d is ready!", pizzaNumber)
+			// Publish the shortfall too, not just successful decrements,
+			// so the restocker can top this ingredient up even when it
+			// never crosses RestockThreshold via a successful bake.
+			select {
+			case p.stockIn <- inventory.StockChange{Ingredient: ingredient.Name, Remaining: short}:
+			case <-ctx.Done():
+			}
 
-		p := PizzaOrder{
-			pizzaNumber: pizzaNumber,
-			message:     msg,
-			success:     success,
+			o.Message = fmt.Sprintf("*** We ran out of %s for pizza #%d!", ingredient.Name, o.PizzaNumber)
+			return o, false
 		}
+	}
 
-		return &p
+	remaining := make(map[string]int, len(o.PizzaType.Ingredients))
+	for _, ingredient := range o.PizzaType.Ingredients {
+		AvailableIngredients[ingredient.Name] -= ingredient.Quantity
+		remaining[ingredient.Name] = AvailableIngredients[ingredient.Name]
 	}
+	p.ingredientMutex.Unlock()
 
-	return &PizzaOrder{
-		pizzaNumber: pizzaNumber,
+	for name, left := range remaining {
+		if left >= RestockThreshold {
+			continue
+		}
+		select {
+		case p.stockIn <- inventory.StockChange{Ingredient: name, Remaining: left}:
+		case <-ctx.Done():
+		}
 	}
+
+	color.Cyan("Received order #%d! for %s", o.PizzaNumber, o.PizzaType.Name)
+	o.Message = fmt.Sprintf("Pizza order #%d is ready!", o.PizzaNumber)
+	o.Success = true
+	return o, true
+}
+
+// box is a pass-through stage kept as an insertion point for middleware like
+// logging, metrics, or an artificial delay.
+func (p *Producer) box(ctx context.Context, in <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	return passThrough(ctx, in)
 }
 
-// decrementIngredient decrements the quantity of a given ingredient in the available stock.
-func decrementIngredient(ingredientName string, quantity int) {
-	AvailableIngredients[ingredientName] -= quantity
+// deliver is a pass-through stage kept as an insertion point for middleware
+// like logging, metrics, or an artificial delay.
+func (p *Producer) deliver(ctx context.Context, in <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	return passThrough(ctx, in)
 }
 
-// pizzeria is a goroutine that```go
-// pizzeria is a goroutine that runs in the background and
-// calls makePizza to try to make one order each time it iterates through
-// the for loop. It executes until it receives something on the quit
-// channel. The quit channel does not receive anything until the consumer
-// sends it (when the number of orders is greater than or equal to the
-// constant NumberOfPizzas).
-func pizzeria(pizzaMaker *Producer, pizzaTypes []PizzaType) {
-	// keep track of which pizza we are making
-	var i = 0
+// passThrough forwards every order from in to a new output channel,
+// unmodified, until in is drained. It ignores ctx deliberately: racing the
+// forwarding send against ctx.Done would let select drop an order that's
+// already been produced, which breaks the graceful-drain guarantee the
+// pipeline depends on (see Start's doc comment). Stages upstream stop
+// producing new orders on cancellation and close their output, which is
+// what eventually drains and closes this channel too.
+func passThrough(_ context.Context, in <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	out := make(chan pipeline.PizzaOrder)
+	errs := make(chan error)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+		for o := range in {
+			out <- o
+		}
+	}()
 
-	// this loop will continue to execute, trying to make pizzas,
-	// until the quit channel receives something.
-	for {
-		pizzaTypeIndex := rand.Intn(len(pizzaTypes))
-		pizzaType := pizzaTypes[pizzaTypeIndex]
+	return out, errs
+}
 
-		currentPizza := makePizza(i, pizzaType)
-		if currentPizza != nil {
-			i = currentPizza.pizzaNumber
-			select {
-			// we tried to make a pizza (we send something to the data channel -- a chan PizzaOrder)
-			case pizzaMaker.data <- *currentPizza:
-			// we want to quit, so send pizzaMaker.quit to the quitChan (a chan error)
-			case quitChan := <-pizzaMaker.quit:
-				// close channels
-				close(pizzaMaker.data)
-				close(quitChan)
-				pizzaMaker.wg.Done() // Signal that the pizzeria goroutine has finished
-				return
+// recordCheckpoint persists each order's final status to the checkpoint
+// file as it passes through, so a later run can skip what already
+// succeeded. It is only wired into the pipeline by RunWithCheckpoint.
+func (p *Producer) recordCheckpoint(ctx context.Context, in <-chan pipeline.PizzaOrder) (<-chan pipeline.PizzaOrder, <-chan error) {
+	out := make(chan pipeline.PizzaOrder)
+	errs := make(chan error)
+
+	go func() {
+		defer close(out)
+		defer close(errs)
+		for o := range in {
+			entry := &CheckpointEntry{Status: StatusSuccess, Retries: o.Retries}
+			if !o.Success {
+				entry.Status = StatusFailed
+				entry.Reason = o.Message
+			}
+
+			p.checkpointMutex.Lock()
+			p.checkpoint.Orders[o.PizzaNumber] = entry
+			err := p.checkpoint.save(p.checkpointPath)
+			p.checkpointMutex.Unlock()
+
+			if err != nil {
+				select {
+				case errs <- fmt.Errorf("producer: save checkpoint: %w", err):
+				case <-ctx.Done():
+					return
+				}
 			}
+
+			// A blocking send, deliberately not raced against ctx.Done: o
+			// has already been recorded to the checkpoint, so it must be
+			// forwarded rather than dropped (see passThrough).
+			out <- o
+		}
+	}()
+
+	return out, errs
+}
+
+// reportStats records a bake worker's final stats so Close can collect them
+// once every worker has finished.
+func (p *Producer) reportStats(stats WorkerStats) {
+	p.statsMutex.Lock()
+	defer p.statsMutex.Unlock()
+	p.stats = append(p.stats, stats)
+}
+
+// logErrors drains the pipeline's merged error channel, printing anything it
+// receives until the pipeline shuts down.
+func (p *Producer) logErrors() {
+	for err := range p.errs {
+		color.Red("pipeline error: %v", err)
+	}
+}
+
+// Close cancels the pipeline and returns the stats collected from every bake
+// worker. It should be called once the caller is done draining the output
+// channel returned by Start.
+func (p *Producer) Close() []WorkerStats {
+	completed := p.ctx.Err() == nil
+	p.cancel()
+	p.inv.Shutdown()
+
+	if p.checkpointPath != "" && completed {
+		now := time.Now()
+		p.checkpointMutex.Lock()
+		p.checkpoint.CompletedAt = &now
+		if err := p.checkpoint.save(p.checkpointPath); err != nil {
+			color.Red("producer: save checkpoint: %v", err)
 		}
+		p.checkpointMutex.Unlock()
 	}
+
+	p.statsMutex.Lock()
+	defer p.statsMutex.Unlock()
+	return p.stats
 }
 
 func main() {
+	nWorkers := flag.Int("workers", 3, "number of bake workers working at once")
+	maxInFlight := flag.Int("max-in-flight", 2, "maximum number of pizzas being baked at once")
+	checkpointPath := flag.String("checkpoint", "", "path to a checkpoint file; if set, the pizzeria resumes from it and can be safely interrupted")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// print out a message
 	color.Cyan("The Pizzeria is open for business!")
 	color.Cyan("----------------------------------")
 
 	// Define pizza types and their required ingredients
-	pizzaTypes := []PizzaType{
+	pizzaTypes := []pipeline.PizzaType{
 		{
 			Name: "Mazza",
-			Ingredients: []Ingredient{
+			Ingredients: []pipeline.Ingredient{
 				{Name: "Tomato sauce", Quantity: 1},
 				{Name: "Mozzarella", Quantity: 1},
 				{Name: "Bacon", Quantity: 1},
@@ -173,7 +501,7 @@ func main() {
 		},
 		{
 			Name: "Napoletana",
-			Ingredients: []Ingredient{
+			Ingredients: []pipeline.Ingredient{
 				{Name: "Tomato sauce", Quantity: 1},
 				{Name: "Mozzarella", Quantity: 1},
 				{Name: "Anchovies", Quantity: 1},
@@ -182,45 +510,53 @@ func main() {
 		},
 	}
 
-	// create a producer
-	pizzaJob := &Producer{
-		data: make(chan PizzaOrder),
-		quit: make(chan chan error),
-		wg:   &sync.WaitGroup{},
-	}
+	// create a producer and start its pipeline
+	pizzaJob := NewProducer(*nWorkers, *maxInFlight, pizzaTypes)
 
-	// Add 1 to the WaitGroup to indicate the pizzeria goroutine
-	pizzaJob.wg.Add(1)
-
-	// run the producer in the background
-	go pizzeria(pizzaJob, pizzaTypes)
+	var orders <-chan pipeline.PizzaOrder
+	if *checkpointPath != "" {
+		var err error
+		orders, err = pizzaJob.RunWithCheckpoint(ctx, *checkpointPath)
+		if err != nil {
+			color.Red("*** Could not start from checkpoint: %v", err)
+			os.Exit(1)
+		}
+	} else {
+		orders = pizzaJob.Start(ctx)
+	}
 
-	// create and run consumer
-	for i := range pizzaJob.data {
-		if i.pizzaNumber <= NumberOfPizzas {
-			if i.success {
-				color.Green(i.message)
-				color.Green("Order #%d is out for delivery!", i.pizzaNumber)
-			} else {
-				color.Red(i.message)
-				color.Red("The customer is really mad!")
-			}
+	// consume delivered orders as they arrive; a SIGINT/SIGTERM cancels ctx,
+	// which winds the pipeline down once in-flight pizzas finish instead of
+	// tearing it down mid-order.
+	for o := range orders {
+		if o.Success {
+			color.Green(o.Message)
+			color.Green("Order #%d is out for delivery!", o.PizzaNumber)
 		} else {
-			color.Cyan("Done making pizzas...")
-			err := pizzaJob.Close()
-			if err != nil {
-				color.Red("*** Error closing channel!", err)
-			}
+			color.Red(o.Message)
+			color.Red("The customer is really mad!")
 		}
 	}
 
-	// Wait for the pizzeria goroutine to finish
-	pizzaJob.wg.Wait()
+	// the output channel is closed once the whole pipeline has drained, so
+	// Close just needs to tear down the pipeline's context and gather stats.
+	stats := pizzaJob.Close()
+
+	var pizzasMade, pizzasFailed, total int
+	for _, s := range stats {
+		pizzasMade += s.Made
+		pizzasFailed += s.Failed
+		total += s.Made + s.Failed
+	}
 
 	// print out the ending message
 	color.Cyan("-----------------")
 	color.Cyan("Done for the day.")
 
+	for _, s := range stats {
+		color.Cyan("Worker #%d made %d and failed %d pizzas.", s.WorkerID, s.Made, s.Failed)
+	}
+
 	color.Cyan("We made %d pizzas, but failed to make %d, with %d attempts in total.", pizzasMade, pizzasFailed, total)
 
 	switch {
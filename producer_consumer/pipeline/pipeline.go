@@ -0,0 +1,101 @@
+// Package pipeline provides a small staged-pipeline abstraction for running
+// pizza orders through a sequence of independently cancellable stages, each
+// running in its own goroutine with its own error channel.
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// Ingredient represents an ingredient with its name and quantity.
+type Ingredient struct {
+	Name     string
+	Quantity int
+}
+
+// PizzaType represents a pizza type with its name and required ingredients.
+type PizzaType struct {
+	Name        string
+	Ingredients []Ingredient
+}
+
+// PizzaOrder describes a pizza order as it moves through the pipeline: which
+// pizza number and type it is, a message describing what has happened to it
+// so far, whether it is still on track to be delivered, and how many times
+// bake had to retry it.
+type PizzaOrder struct {
+	PizzaNumber int
+	PizzaType   PizzaType
+	Message     string
+	Success     bool
+	Retries     int
+}
+
+// Stage is one step of a pizza pipeline. It reads orders from in and returns
+// a channel of orders to hand to the next stage, plus an error channel it
+// uses to report problems it hits along the way. A Stage closes both
+// channels once in is drained or ctx is cancelled, so it can be daisy-chained
+// with other stages.
+type Stage func(ctx context.Context, in <-chan PizzaOrder) (<-chan PizzaOrder, <-chan error)
+
+// Pipeline wires a sequence of stages together, feeding each stage's output
+// into the next stage's input.
+type Pipeline struct{}
+
+// New creates a Pipeline.
+func New() *Pipeline {
+	return &Pipeline{}
+}
+
+// Run chains stages together starting from in and returns the final stage's
+// output channel along with a single error channel fanned in from every
+// stage. The whole chain is cancelled when ctx is done.
+func (p *Pipeline) Run(ctx context.Context, in <-chan PizzaOrder, stages ...Stage) (<-chan PizzaOrder, <-chan error) {
+	errChans := make([]<-chan error, 0, len(stages))
+
+	out := in
+	for _, stage := range stages {
+		var errCh <-chan error
+		out, errCh = stage(ctx, out)
+		errChans = append(errChans, errCh)
+	}
+
+	return out, mergeErrors(ctx, errChans...)
+}
+
+// mergeErrors fans multiple error channels into one, closing it once every
+// input channel is drained or ctx is cancelled.
+func mergeErrors(ctx context.Context, chans ...<-chan error) <-chan error {
+	out := make(chan error)
+	var wg sync.WaitGroup
+	wg.Add(len(chans))
+
+	for _, c := range chans {
+		go func(c <-chan error) {
+			defer wg.Done()
+			for {
+				select {
+				case err, ok := <-c:
+					if !ok {
+						return
+					}
+					select {
+					case out <- err:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(c)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
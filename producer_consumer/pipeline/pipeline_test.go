@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// doubler is a trivial Stage that forwards each order with PizzaNumber
+// doubled, used to verify Run chains stages in order.
+func doubler(ctx context.Context, in <-chan PizzaOrder) (<-chan PizzaOrder, <-chan error) {
+	out := make(chan PizzaOrder)
+	errs := make(chan error)
+	go func() {
+		defer close(out)
+		defer close(errs)
+		for o := range in {
+			o.PizzaNumber *= 2
+			select {
+			case out <- o:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, errs
+}
+
+func TestPipelineRunChainsStages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan PizzaOrder, 1)
+	in <- PizzaOrder{PizzaNumber: 1}
+	close(in)
+
+	p := New()
+	out, errs := p.Run(ctx, in, doubler, doubler)
+
+	o, ok := <-out
+	if !ok {
+		t.Fatal("expected an order out of the pipeline, got none")
+	}
+	if o.PizzaNumber != 4 {
+		t.Errorf("PizzaNumber = %d, want 4 (1 doubled twice)", o.PizzaNumber)
+	}
+
+	if _, ok := <-out; ok {
+		t.Error("expected out to be closed after the single order")
+	}
+	if _, ok := <-errs; ok {
+		t.Error("expected errs to be closed with no errors published")
+	}
+}
+
+func TestMergeErrorsFansInAndCloses(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := make(chan error, 1)
+	b := make(chan error, 1)
+	a <- errTest("a")
+	b <- errTest("b")
+	close(a)
+	close(b)
+
+	merged := mergeErrors(ctx, a, b)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case err, ok := <-merged:
+			if !ok {
+				t.Fatalf("merged closed early after %d errors", i)
+			}
+			seen[err.Error()] = true
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for merged errors")
+		}
+	}
+	if !seen["a"] || !seen["b"] {
+		t.Errorf("seen = %v, want both a and b", seen)
+	}
+
+	if _, ok := <-merged; ok {
+		t.Error("expected merged to be closed once both inputs are drained")
+	}
+}
+
+type errTest string
+
+func (e errTest) Error() string { return string(e) }